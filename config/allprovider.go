@@ -0,0 +1,28 @@
+// Copyright 2024 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// AllProvider is the site configuration surface consumed by this package's
+// callers. It's a small slice of the real, much larger AllProvider from the
+// full Hugo configuration layer — only the methods helpers.ContentSpec
+// actually needs are declared here.
+type AllProvider interface {
+	// SummaryLength is the default number of words (or, for CJK content,
+	// runes) a generated summary is truncated to.
+	SummaryLength() int
+
+	// Summary returns the site's summary generation and truncation config,
+	// decoded from the summary.* config keys; see DecodeSummaryConfig.
+	Summary() SummaryConfig
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "github.com/mitchellh/mapstructure"
+
+// SummaryConfig configures automatic summary generation and truncation.
+type SummaryConfig struct {
+	// HasCJKLanguage enables CJK-aware word counting and sentence-boundary
+	// detection when truncating summaries, since Chinese, Japanese and
+	// Korean text doesn't use whitespace between words.
+	HasCJKLanguage bool
+
+	// CJKThreshold is the ratio, in the range [0,1], of CJK runes that a
+	// piece of content must exceed before it's treated as CJK for summary
+	// purposes. Only read when HasCJKLanguage is enabled.
+	CJKThreshold float64
+}
+
+// DefaultCJKThreshold is used when summary.cjkThreshold isn't set.
+const DefaultCJKThreshold = 0.2
+
+// init applies defaults for zero-valued fields. It's called once, by
+// DecodeSummaryConfig, so every SummaryConfig DecodeSummaryConfig returns
+// without an error already has its defaults applied.
+func (c SummaryConfig) init() SummaryConfig {
+	if c.CJKThreshold == 0 {
+		c.CJKThreshold = DefaultCJKThreshold
+	}
+	return c
+}
+
+// DecodeSummaryConfig decodes the "summary" section of the site
+// configuration (e.g. from hugo.toml's [summary] table) into a
+// SummaryConfig, applying defaults for anything left unset.
+func DecodeSummaryConfig(in map[string]any) (SummaryConfig, error) {
+	var s SummaryConfig
+	if in == nil {
+		return s.init(), nil
+	}
+	if err := mapstructure.WeakDecode(in, &s); err != nil {
+		return s, err
+	}
+	return s.init(), nil
+}
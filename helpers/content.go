@@ -50,14 +50,32 @@ type ContentSpec struct {
 	anchorNameSanitizer converter.AnchorNameSanitizer
 	getRenderer         func(t hooks.RendererType, id any) any
 
+	// hasCJKLanguage and cjkThreshold cache cfg.Summary() so the CJK checks
+	// in the hot TotalWords/TruncateWords* paths don't re-read config on
+	// every call.
+	hasCJKLanguage bool
+	cjkThreshold   float64
+
 	Cfg config.AllProvider
 }
 
 // NewContentSpec returns a ContentSpec initialized
 // with the appropriate fields from the given config.Provider.
 func NewContentSpec(cfg config.AllProvider, logger loggers.Logger, contentFs afero.Fs, ex *hexec.Exec) (*ContentSpec, error) {
+	summaryCfg := cfg.Summary()
+	cjkThreshold := summaryCfg.CJKThreshold
+	if cjkThreshold == 0 {
+		// A cfg whose Summary() wasn't built by DecodeSummaryConfig may
+		// return a zero threshold; apply the same default DecodeSummaryConfig
+		// would so that leaving summary.cjkThreshold unset doesn't silently
+		// mean "any content with a single CJK rune counts as CJK"
+		// (cjkRuneRatio(s) > 0).
+		cjkThreshold = config.DefaultCJKThreshold
+	}
 	spec := &ContentSpec{
-		Cfg: cfg,
+		Cfg:            cfg,
+		hasCJKLanguage: summaryCfg.HasCJKLanguage,
+		cjkThreshold:   cjkThreshold,
 	}
 
 	converterProvider, err := markup.NewConverterProvider(converter.ProviderConfig{
@@ -101,7 +119,14 @@ func BytesToHTML(b []byte) template.HTML {
 	return template.HTML(string(b))
 }
 
-// ExtractTOC extracts Table of Contents from content.
+// ExtractTOC extracts Table of Contents from content by scraping the <nav>
+// block a Converter inlined into its HTML output. It's legacy: content from a
+// Converter whose Result doesn't provide a structured TableOfContents, and
+// which still inlines <nav> markup, is the only case it's still needed for;
+// content with no <nav> is returned unchanged. Prefer ExtractTOCFromResult,
+// which returns the structured model directly for any Converter whose
+// Result implements converter.TableOfContentsProvider and only falls back
+// to this scraper otherwise.
 func ExtractTOC(content []byte) (newcontent []byte, toc []byte) {
 	if !bytes.Contains(content, []byte("<nav>")) {
 		return content, nil
@@ -140,6 +165,106 @@ func ExtractTOC(content []byte) (newcontent []byte, toc []byte) {
 	return
 }
 
+// ExtractTOCFromResult returns the structured TableOfContents for res, using
+// the renderer-driven path: if res's Converter populated one (see
+// converter.TableOfContentsProvider), that's returned directly, no HTML
+// scraping involved. Converters that don't implement it yet fall back to
+// ExtractTOC's legacy <nav> scraping to strip the inlined markup from
+// content, but can't recover a structured TableOfContents from it.
+func (c *ContentSpec) ExtractTOCFromResult(content []byte, res any) (newcontent []byte, toc converter.TableOfContents) {
+	if tp, ok := res.(converter.TableOfContentsProvider); ok {
+		return content, tp.TableOfContents()
+	}
+	newcontent, _ = ExtractTOC(content)
+	return newcontent, toc
+}
+
+// TOCRenderOptions configures how a TableOfContents is rendered to HTML by
+// RenderTOC.
+type TOCRenderOptions struct {
+	// StartLevel is the smallest TOCEntry.Level (1 for an <h1> heading) to include.
+	StartLevel int
+	// EndLevel is the largest TOCEntry.Level to include.
+	EndLevel int
+	// Ordered renders the list as <ol> instead of <ul>.
+	Ordered bool
+	// Template, when set, overrides the built-in nested <ul>/<ol> rendering;
+	// it's executed with the level-filtered []converter.TOCEntry tree as its
+	// data, e.g. to emit a custom markup shape or a non-HTML representation.
+	Template *template.Template
+}
+
+// DefaultTOCRenderOptions reproduce the <ul>-only, all-levels markup that
+// ExtractTOC has always produced; see RenderTOCDefault.
+var DefaultTOCRenderOptions = TOCRenderOptions{StartLevel: 1, EndLevel: 999}
+
+// RenderTOC renders toc as nested HTML lists, restricted to the heading
+// levels selected by opts, or via opts.Template if set.
+func (c *ContentSpec) RenderTOC(toc converter.TableOfContents, opts TOCRenderOptions) ([]byte, error) {
+	visible := filterTOCEntries(toc.Entries, opts)
+
+	var buf bytes.Buffer
+	if opts.Template != nil {
+		if err := opts.Template.Execute(&buf, visible); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	renderTOCEntries(&buf, visible, opts)
+	return buf.Bytes(), nil
+}
+
+// RenderTOCDefault renders toc with DefaultTOCRenderOptions, matching
+// ExtractTOC's historical <nav id="TableOfContents"><ul>...</ul></nav>
+// markup (without the <nav> wrapper, which callers add themselves).
+func (c *ContentSpec) RenderTOCDefault(toc converter.TableOfContents) []byte {
+	b, _ := c.RenderTOC(toc, DefaultTOCRenderOptions)
+	return b
+}
+
+// filterTOCEntries returns the subset of entries (and, recursively, their
+// children) whose Level falls within [opts.StartLevel, opts.EndLevel]. An
+// entry outside that range is dropped, but its in-range descendants are
+// promoted in its place rather than discarded, matching Hugo's startLevel
+// semantics (e.g. StartLevel: 2 surfaces the level-2 headings as top-level
+// entries even though their level-1 parents are filtered out).
+func filterTOCEntries(entries []converter.TOCEntry, opts TOCRenderOptions) []converter.TOCEntry {
+	var visible []converter.TOCEntry
+	for _, e := range entries {
+		children := filterTOCEntries(e.Children, opts)
+		if e.Level < opts.StartLevel || e.Level > opts.EndLevel {
+			visible = append(visible, children...)
+			continue
+		}
+		e.Children = children
+		visible = append(visible, e)
+	}
+	return visible
+}
+
+func renderTOCEntries(buf *bytes.Buffer, entries []converter.TOCEntry, opts TOCRenderOptions) {
+	if len(entries) == 0 {
+		return
+	}
+
+	listTag := "ul"
+	if opts.Ordered {
+		listTag = "ol"
+	}
+
+	buf.WriteString("<" + listTag + ">\n")
+	for _, e := range entries {
+		buf.WriteString(`<li><a href="#` + e.ID + `">` + e.Text + `</a>`)
+		if len(e.Children) > 0 {
+			buf.WriteString("\n")
+			renderTOCEntries(buf, e.Children, opts)
+		}
+		buf.WriteString("</li>\n")
+	}
+	buf.WriteString("</" + listTag + ">\n")
+}
+
 func (c *ContentSpec) SanitizeAnchorName(s string) string {
 	return c.anchorNameSanitizer.SanitizeAnchorName(s)
 }
@@ -165,6 +290,12 @@ func (c *ContentSpec) ResolveMarkup(in string) string {
 // TotalWords counts instance of one or more consecutive white space
 // characters, as defined by unicode.IsSpace, in s.
 // This is a cheaper way of word counting than the obvious len(strings.Fields(s)).
+//
+// Word- and rune-count call sites that have a *ContentSpec available (e.g.
+// page build's word count and summary truncation) should call the
+// (*ContentSpec).TotalWords method instead: it dispatches to this function
+// only for non-CJK content, so calling this package-level function directly
+// bypasses CJK-aware counting entirely.
 func TotalWords(s string) int {
 	n := 0
 	inWord := false
@@ -178,7 +309,112 @@ func TotalWords(s string) int {
 	return n
 }
 
+// isCJKRune reports whether r belongs to one of the CJK scripts (Han,
+// Hiragana, Katakana or Hangul), none of which use whitespace to separate
+// words.
+func isCJKRune(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
+
+// isCJKEndOfSentence reports whether r ends a CJK sentence. Opening
+// quotation marks (「, 『) aren't included here: they open rather than close
+// a quotation, so treating them as terminators would cut a summary right
+// after an opening quote. See truncateToWholeSentenceCJK, which tracks
+// quote state separately and stops on a closing quote instead.
+func isCJKEndOfSentence(r rune) bool {
+	switch r {
+	case '。', '．', '？', '！', '…', '」', '』':
+		return true
+	}
+	return false
+}
+
+// isCJKOpenQuote and isCJKCloseQuote report whether r opens or closes a CJK
+// quotation, so sentence-terminator scanning can skip over text quoted
+// mid-sentence instead of stopping at punctuation inside the quote.
+func isCJKOpenQuote(r rune) bool {
+	return r == '「' || r == '『'
+}
+
+func isCJKCloseQuote(r rune) bool {
+	return r == '」' || r == '』'
+}
+
+// cjkRuneRatio returns the ratio of CJK runes to the total number of
+// non-space runes in s.
+func cjkRuneRatio(s string) float64 {
+	var total, cjk int
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		total++
+		if isCJKRune(r) {
+			cjk++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(cjk) / float64(total)
+}
+
+// isCJKContent reports whether s should be treated as CJK content, i.e.
+// hasCJKLanguage is enabled and the ratio of CJK runes in s is above
+// threshold. It's kept free of *ContentSpec so it can be unit tested
+// without a config.AllProvider.
+func isCJKContent(s string, hasCJKLanguage bool, threshold float64) bool {
+	return hasCJKLanguage && cjkRuneRatio(s) > threshold
+}
+
+// isCJK reports whether s should be treated as CJK content for this site,
+// per summary.hasCJKLanguage and summary.cjkThreshold.
+func (c *ContentSpec) isCJK(s string) bool {
+	return isCJKContent(s, c.hasCJKLanguage, c.cjkThreshold)
+}
+
+// TotalWords returns the word count of s. For CJK content (see isCJK)
+// every CJK rune is counted as a word, since CJK scripts do not use
+// whitespace between words; other content falls back to the
+// whitespace-based TotalWords.
+func (c *ContentSpec) TotalWords(s string) int {
+	if c.isCJK(s) {
+		return countWordsCJK(s)
+	}
+	return TotalWords(s)
+}
+
+// countWordsCJK counts each CJK rune as a word, and runs of non-CJK,
+// non-space runes as a single word, as TotalWords does.
+func countWordsCJK(s string) int {
+	n := 0
+	inWord := false
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			inWord = false
+		case isCJKRune(r):
+			n++
+			inWord = false
+		case !inWord:
+			n++
+			inWord = true
+		}
+	}
+	return n
+}
+
 // TruncateWordsByRune truncates words by runes.
+//
+// Unlike TotalWords/TruncateWordsToWholeSentence, this doesn't need a
+// separate CJK path: for any word that isn't pure ASCII (len(word) !=
+// runeCount), it already counts and truncates rune-by-rune rather than
+// treating the whole word as a single unit, so a run of CJK text packed
+// into one "word" by an upstream whitespace split is already budgeted one
+// rune at a time.
 func (c *ContentSpec) TruncateWordsByRune(in []string) (string, bool) {
 	words := make([]string, len(in))
 	copy(words, in)
@@ -210,6 +446,10 @@ func (c *ContentSpec) TruncateWordsByRune(in []string) (string, bool) {
 // TruncateWordsToWholeSentence takes content and truncates to whole sentence
 // limited by max number of words. It also returns whether it is truncated.
 func (c *ContentSpec) TruncateWordsToWholeSentence(s string) (string, bool) {
+	if c.isCJK(s) {
+		return c.truncateWordsToWholeSentenceCJK(s)
+	}
+
 	var (
 		wordCount     = 0
 		lastWordIndex = -1
@@ -247,6 +487,83 @@ func (c *ContentSpec) TruncateWordsToWholeSentence(s string) (string, bool) {
 	return strings.TrimSpace(s[:endIndex]), endIndex < len(s)
 }
 
+// truncateWordsToWholeSentenceCJK is the CJK-aware counterpart of
+// TruncateWordsToWholeSentence: each CJK rune counts as a word, and the cut
+// point is snapped to the nearest CJK or ASCII sentence terminator.
+func (c *ContentSpec) truncateWordsToWholeSentenceCJK(s string) (string, bool) {
+	return truncateToWholeSentenceCJK(s, c.Cfg.SummaryLength())
+}
+
+// truncateToWholeSentenceCJK is the pure core of truncateWordsToWholeSentenceCJK,
+// taking summaryLength as a parameter so it can be unit tested without a
+// config.AllProvider.
+func truncateToWholeSentenceCJK(s string, summaryLength int) (string, bool) {
+	var (
+		wordCount     = 0
+		lastWordIndex = -1
+		inWord        = false
+		inQuote       = false
+		// startInQuote is the quote state at lastWordIndex, so the
+		// terminator scan below knows whether it starts inside a quotation.
+		startInQuote = false
+	)
+
+	for i, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			inWord = false
+		case isCJKRune(r):
+			wordCount++
+			lastWordIndex = i + utf8.RuneLen(r)
+			startInQuote = inQuote
+			inWord = false
+		case !inWord:
+			wordCount++
+			lastWordIndex = i
+			startInQuote = inQuote
+			inWord = true
+		}
+
+		if isCJKOpenQuote(r) {
+			inQuote = true
+		} else if isCJKCloseQuote(r) {
+			inQuote = false
+		}
+
+		if wordCount >= summaryLength {
+			break
+		}
+	}
+
+	if lastWordIndex == -1 {
+		return s, false
+	}
+
+	endIndex := -1
+	inQuote = startInQuote
+
+	for j, r := range s[lastWordIndex:] {
+		switch {
+		case isCJKOpenQuote(r):
+			inQuote = true
+			continue
+		case isCJKCloseQuote(r):
+			inQuote = false
+		}
+
+		if !inQuote && (isEndOfSentence(r) || isCJKEndOfSentence(r)) {
+			endIndex = j + lastWordIndex + utf8.RuneLen(r)
+			break
+		}
+	}
+
+	if endIndex == -1 {
+		return s, false
+	}
+
+	return strings.TrimSpace(s[:endIndex]), endIndex < len(s)
+}
+
 // TrimShortHTML removes the <p>/</p> tags from HTML input in the situation
 // where said tags are the only <p> tags in the input and enclose the content
 // of the input (whitespace excluded).
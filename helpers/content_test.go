@@ -0,0 +1,119 @@
+// Copyright 2024 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import "testing"
+
+func TestCJKRuneRatio(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		s    string
+		want float64
+	}{
+		{"pure ASCII", "hello world", 0},
+		{"pure CJK", "你好世界", 1},
+		{"mixed", "hello 你好", 2.0 / 7.0},
+		{"empty", "", 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := cjkRuneRatio(test.s)
+			if diff := got - test.want; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("cjkRuneRatio(%q) = %v, want %v", test.s, got, test.want)
+			}
+		})
+	}
+}
+
+func TestIsCJKContent(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		s         string
+		enabled   bool
+		threshold float64
+		want      bool
+	}{
+		{"disabled site", "你好世界", false, 0.2, false},
+		{"enabled, above threshold", "你好世界", true, 0.2, true},
+		{"enabled, below threshold", "mostly english 你", true, 0.5, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isCJKContent(test.s, test.enabled, test.threshold); got != test.want {
+				t.Errorf("isCJKContent(%q, %v, %v) = %v, want %v", test.s, test.enabled, test.threshold, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCountWordsCJK(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		s    string
+		want int
+	}{
+		{"pure CJK, no spaces", "我爱你中国", 5},
+		{"CJK with ASCII mixed in", "我爱 Hugo 和中国", 2 + 1 + 3},
+		{"pure ASCII still splits on whitespace", "hello world", 2},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := countWordsCJK(test.s); got != test.want {
+				t.Errorf("countWordsCJK(%q) = %d, want %d", test.s, got, test.want)
+			}
+		})
+	}
+}
+
+func TestTruncateToWholeSentenceCJK(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		s             string
+		summaryLength int
+		want          string
+		wantTruncated bool
+	}{
+		{
+			name:          "cuts at the CJK full stop",
+			s:             "我爱中国。我也爱Hugo。",
+			summaryLength: 3,
+			want:          "我爱中国。",
+			wantTruncated: true,
+		},
+		{
+			// The summary budget (3 words) is exhausted at the opening "「"
+			// (the third word), but the cut point must not land on punctuation inside
+			// the quote (e.g. nothing here should terminate on "「" or a
+			// comma); it should extend through the quote and stop at the
+			// closing "」", the nearest real sentence boundary.
+			name:          "extends through a quotation instead of cutting inside it",
+			s:             "他说「这是一个用来测试摘要截断功能的句子」结束。",
+			summaryLength: 3,
+			want:          "他说「这是一个用来测试摘要截断功能的句子」",
+			wantTruncated: true,
+		},
+		{
+			name:          "no terminator found returns original",
+			s:             "我爱中国我也爱Hugo",
+			summaryLength: 2,
+			want:          "我爱中国我也爱Hugo",
+			wantTruncated: false,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, truncated := truncateToWholeSentenceCJK(test.s, test.summaryLength)
+			if got != test.want || truncated != test.wantTruncated {
+				t.Errorf("truncateToWholeSentenceCJK(%q, %d) = (%q, %v), want (%q, %v)",
+					test.s, test.summaryLength, got, truncated, test.want, test.wantTruncated)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/gohugoio/hugo/markup/converter"
+	"github.com/gohugoio/hugo/markup/goldmark"
+)
+
+// TestExtractTOCFromResultGoldmark exercises the renderer-driven TOC path
+// end-to-end, using goldmark.Converter as a real (if minimal)
+// converter.TableOfContentsProvider implementation rather than the
+// fakeTOCResult stand-in used elsewhere in this package's tests.
+func TestExtractTOCFromResultGoldmark(t *testing.T) {
+	conv := &goldmark.Converter{}
+	res, err := conv.Convert([]byte("# Title\n\nBody text.\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &ContentSpec{}
+	newcontent, toc := c.ExtractTOCFromResult(res.Bytes(), res)
+	if string(newcontent) != string(res.Bytes()) {
+		t.Errorf("content was modified: got %q", newcontent)
+	}
+	if len(toc.Entries) != 1 || toc.Entries[0].ID != "title" {
+		t.Errorf("toc.Entries = %+v", toc.Entries)
+	}
+
+	got := c.RenderTOCDefault(toc)
+	want := `<ul>
+<li><a href="#title">Title</a></li>
+</ul>
+`
+	if string(got) != want {
+		t.Errorf("RenderTOCDefault() = %q, want %q", got, want)
+	}
+}
+
+var _ converter.TableOfContentsProvider = goldmark.Result{}
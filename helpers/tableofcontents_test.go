@@ -0,0 +1,167 @@
+// Copyright 2024 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/gohugoio/hugo/markup/converter"
+)
+
+func nestedTOC() converter.TableOfContents {
+	return converter.TableOfContents{
+		Entries: []converter.TOCEntry{
+			{
+				Level: 1, ID: "intro", Text: "Intro",
+			},
+			{
+				Level: 1, ID: "usage", Text: "Usage",
+				Children: []converter.TOCEntry{
+					{Level: 2, ID: "usage-basic", Text: "Basic"},
+					{Level: 2, ID: "usage-advanced", Text: "Advanced"},
+				},
+			},
+		},
+	}
+}
+
+func TestRenderTOCDefault(t *testing.T) {
+	c := &ContentSpec{}
+	got := string(c.RenderTOCDefault(nestedTOC()))
+	want := `<ul>
+<li><a href="#intro">Intro</a></li>
+<li><a href="#usage">Usage</a>
+<ul>
+<li><a href="#usage-basic">Basic</a></li>
+<li><a href="#usage-advanced">Advanced</a></li>
+</ul>
+</li>
+</ul>
+`
+	if got != want {
+		t.Errorf("RenderTOCDefault() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestRenderTOCLevelFiltering(t *testing.T) {
+	c := &ContentSpec{}
+	got, err := c.RenderTOC(nestedTOC(), TOCRenderOptions{StartLevel: 1, EndLevel: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<ul>
+<li><a href="#intro">Intro</a></li>
+<li><a href="#usage">Usage</a></li>
+</ul>
+`
+	if string(got) != want {
+		t.Errorf("RenderTOC(EndLevel=1) =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestRenderTOCStartLevelPromotesChildren(t *testing.T) {
+	c := &ContentSpec{}
+	got, err := c.RenderTOC(nestedTOC(), TOCRenderOptions{StartLevel: 2, EndLevel: 999})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Both level-1 entries are filtered out by StartLevel: 2, but "usage"'s
+	// level-2 children must be promoted rather than dropped along with it;
+	// "intro" has no children, so it contributes nothing.
+	want := `<ul>
+<li><a href="#usage-basic">Basic</a></li>
+<li><a href="#usage-advanced">Advanced</a></li>
+</ul>
+`
+	if string(got) != want {
+		t.Errorf("RenderTOC(StartLevel=2) =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestRenderTOCOrdered(t *testing.T) {
+	c := &ContentSpec{}
+	got, err := c.RenderTOC(converter.TableOfContents{
+		Entries: []converter.TOCEntry{{Level: 1, ID: "a", Text: "A"}},
+	}, TOCRenderOptions{StartLevel: 1, EndLevel: 999, Ordered: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<ol>\n<li><a href=\"#a\">A</a></li>\n</ol>\n"
+	if string(got) != want {
+		t.Errorf("RenderTOC(Ordered) = %q, want %q", got, want)
+	}
+}
+
+func TestRenderTOCEmpty(t *testing.T) {
+	c := &ContentSpec{}
+	got, err := c.RenderTOC(converter.TableOfContents{}, DefaultTOCRenderOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("RenderTOC(empty) = %q, want empty", got)
+	}
+}
+
+func TestRenderTOCTemplate(t *testing.T) {
+	c := &ContentSpec{}
+	tmpl := template.Must(template.New("toc").Parse(`{{range .}}{{.ID}}={{.Text}};{{end}}`))
+	got, err := c.RenderTOC(nestedTOC(), TOCRenderOptions{
+		StartLevel: 1, EndLevel: 1,
+		Template: tmpl,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "intro=Intro;usage=Usage;"
+	if string(got) != want {
+		t.Errorf("RenderTOC(Template) = %q, want %q", got, want)
+	}
+}
+
+type fakeTOCResult struct {
+	toc converter.TableOfContents
+}
+
+func (f fakeTOCResult) TableOfContents() converter.TableOfContents {
+	return f.toc
+}
+
+func TestExtractTOCFromResult(t *testing.T) {
+	c := &ContentSpec{}
+
+	t.Run("structured provider is used directly, content untouched", func(t *testing.T) {
+		res := fakeTOCResult{toc: nestedTOC()}
+		content := []byte("<p>body</p>")
+		newcontent, toc := c.ExtractTOCFromResult(content, res)
+		if string(newcontent) != string(content) {
+			t.Errorf("content was modified: got %q", newcontent)
+		}
+		if len(toc.Entries) != 2 {
+			t.Errorf("got %d entries, want 2", len(toc.Entries))
+		}
+	})
+
+	t.Run("falls back to legacy ExtractTOC when not a provider", func(t *testing.T) {
+		content := []byte("foo<nav>\n<ul><li><a href=\"#a\">A</a></li></ul>\n</nav>bar")
+		newcontent, toc := c.ExtractTOCFromResult(content, struct{}{})
+		if len(toc.Entries) != 0 {
+			t.Errorf("got %d entries, want 0 (legacy path has no structured TOC)", len(toc.Entries))
+		}
+		if string(newcontent) != "foobar" {
+			t.Errorf("newcontent = %q, want %q", newcontent, "foobar")
+		}
+	})
+}
@@ -0,0 +1,42 @@
+// Copyright 2024 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package converter
+
+// TOCEntry is a single heading in a TableOfContents. Level is the heading
+// level of the source element (1 for <h1>), not its nesting depth within the
+// TOC tree: a top-level entry can have Level 2, and a child's Level can be
+// more than one above its parent's.
+type TOCEntry struct {
+	Level    int
+	ID       string
+	Text     string
+	Children []TOCEntry
+}
+
+// TableOfContents is a structured, renderer-independent table of contents
+// for a page, built by a Converter while it converts content rather than
+// scraped back out of the rendered HTML. This is what makes JSON/YAML TOC
+// output and arbitrary nested-list markup possible for headless sites.
+type TableOfContents struct {
+	Entries []TOCEntry
+}
+
+// TableOfContentsProvider is implemented by a Result whose Converter built a
+// TableOfContents while converting the content. Converters that don't
+// implement it (or content types with no headings) have no structured TOC;
+// callers needing backwards-compatible HTML can still fall back to
+// helpers.ExtractTOC's legacy <nav> scraping in that case.
+type TableOfContentsProvider interface {
+	TableOfContents() TableOfContents
+}
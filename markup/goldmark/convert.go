@@ -0,0 +1,145 @@
+// Copyright 2024 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package goldmark converts Markdown to HTML. This snapshot doesn't vendor
+// the real yuin/goldmark AST parser/renderer it's named after, so Convert
+// below only understands ATX (#) headings and blank-line-separated
+// paragraphs; it exists to give converter.TableOfContentsProvider a real
+// implementation to flow through helpers.ExtractTOCFromResult end-to-end,
+// not to be a drop-in replacement for the full renderer.
+package goldmark
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/gohugoio/hugo/markup/converter"
+)
+
+// Converter converts Markdown source to HTML, building a structured
+// converter.TableOfContents as it walks the headings.
+type Converter struct{}
+
+// New returns a Converter. ctx is currently unused; it's accepted to match
+// the markup.Provider shape the rest of this package's callers expect.
+func New(ctx converter.DocumentContext) (converter.Converter, error) {
+	return &Converter{}, nil
+}
+
+// Result is the output of Converter.Convert. It implements converter.Result
+// and converter.TableOfContentsProvider, so callers that only know about
+// converter.Result (e.g. helpers.ExtractTOCFromResult) can type-assert their
+// way to the structured ToC without re-scraping the rendered HTML.
+type Result struct {
+	body []byte
+	toc  converter.TableOfContents
+}
+
+// Bytes returns the rendered HTML body.
+func (r Result) Bytes() []byte {
+	return r.body
+}
+
+// TableOfContents returns the heading tree built while converting.
+func (r Result) TableOfContents() converter.TableOfContents {
+	return r.toc
+}
+
+// Convert renders src to HTML, returning a Result whose TableOfContents
+// reflects the ATX headings found in src.
+func (c *Converter) Convert(src []byte) (converter.Result, error) {
+	var (
+		body  bytes.Buffer
+		stack []*converter.TOCEntry
+		roots []converter.TOCEntry
+		para  []string
+	)
+
+	flushPara := func() {
+		if len(para) == 0 {
+			return
+		}
+		body.WriteString("<p>" + strings.Join(para, " ") + "</p>\n")
+		para = para[:0]
+	}
+
+	for _, line := range strings.Split(string(src), "\n") {
+		level, text, ok := parseATXHeading(line)
+		if !ok {
+			if strings.TrimSpace(line) == "" {
+				flushPara()
+			} else {
+				para = append(para, strings.TrimSpace(line))
+			}
+			continue
+		}
+
+		flushPara()
+		id := slugify(text)
+		body.WriteString(fmt.Sprintf("<h%d id=%q>%s</h%d>\n", level, id, text, level))
+
+		entry := converter.TOCEntry{Level: level, ID: id, Text: text}
+		for len(stack) > 0 && stack[len(stack)-1].Level >= level {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			roots = append(roots, entry)
+			stack = append(stack, &roots[len(roots)-1])
+		} else {
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, entry)
+			stack = append(stack, &parent.Children[len(parent.Children)-1])
+		}
+	}
+	flushPara()
+
+	return Result{
+		body: body.Bytes(),
+		toc:  converter.TableOfContents{Entries: roots},
+	}, nil
+}
+
+// parseATXHeading reports whether line is an ATX heading ("# Title"),
+// returning its level (1-6) and trimmed text.
+func parseATXHeading(line string) (level int, text string, ok bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	for level < 6 && level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level == 0 || level >= len(trimmed) || trimmed[level] != ' ' {
+		return 0, "", false
+	}
+	return level, strings.TrimSpace(trimmed[level+1:]), true
+}
+
+// slugify turns heading text into a lowercase, hyphenated anchor ID. It's a
+// simplified stand-in for the real anchor name sanitizer used elsewhere in
+// this package family.
+func slugify(s string) string {
+	var b strings.Builder
+	lastHyphen := true
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			b.WriteRune(r)
+			lastHyphen = false
+		default:
+			if !lastHyphen {
+				b.WriteByte('-')
+				lastHyphen = true
+			}
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goldmark
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConverterConvert(t *testing.T) {
+	c := &Converter{}
+	res, err := c.Convert([]byte(`# Intro
+
+Some text.
+
+# Usage
+
+More text.
+
+## Basic
+
+Details.
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	toc := res.(Result).TableOfContents()
+	if len(toc.Entries) != 2 {
+		t.Fatalf("got %d top-level entries, want 2", len(toc.Entries))
+	}
+	if toc.Entries[0].ID != "intro" || toc.Entries[0].Text != "Intro" {
+		t.Errorf("entries[0] = %+v", toc.Entries[0])
+	}
+	usage := toc.Entries[1]
+	if usage.ID != "usage" || len(usage.Children) != 1 {
+		t.Fatalf("entries[1] = %+v", usage)
+	}
+	if usage.Children[0].ID != "basic" || usage.Children[0].Level != 2 {
+		t.Errorf("usage.Children[0] = %+v", usage.Children[0])
+	}
+
+	body := string(res.Bytes())
+	if !strings.Contains(body, `<h1 id="intro">Intro</h1>`) || !strings.Contains(body, "<p>Some text.</p>") {
+		t.Errorf("Bytes() = %q", body)
+	}
+}
+
+func TestParseATXHeading(t *testing.T) {
+	for _, test := range []struct {
+		line      string
+		wantLevel int
+		wantText  string
+		wantOK    bool
+	}{
+		{"# Title", 1, "Title", true},
+		{"### Sub Title", 3, "Sub Title", true},
+		{"#NotAHeading", 0, "", false},
+		{"plain text", 0, "", false},
+	} {
+		level, text, ok := parseATXHeading(test.line)
+		if level != test.wantLevel || text != test.wantText || ok != test.wantOK {
+			t.Errorf("parseATXHeading(%q) = (%d, %q, %v), want (%d, %q, %v)",
+				test.line, level, text, ok, test.wantLevel, test.wantText, test.wantOK)
+		}
+	}
+}
+